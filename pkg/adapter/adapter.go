@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adapter is the RabbitmqSource receive adapter: it turns each
+// delivery read off a RabbitMQ queue into a CloudEvent via the converters
+// registry, and publishes it to the source's Sink using cloudevents-sdk-go
+// v2. Consuming from the queue is left to the caller, which passes each
+// delivery to Adapter.Handle.
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"knative.dev/eventing-rabbitmq/pkg/adapter/converters"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// Delivery is the subset of an AMQP delivery the adapter needs. It's kept
+// narrow so this package doesn't have to depend on a particular AMQP
+// client library, but its field names and types mirror the corresponding
+// amqp091-go Delivery fields so callers can populate it directly from one.
+type Delivery struct {
+	Body []byte
+	// ContentType is the AMQP message's content-type property, consulted
+	// by converters.ResolveFormat when the source has no static
+	// PayloadFormat of its own.
+	ContentType string
+	// Headers is the AMQP message's headers table, consulted by
+	// converters.ResolveFormat for a converters.HeaderFormat entry before
+	// falling back to ContentType.
+	Headers map[string]interface{}
+}
+
+// Adapter converts Deliveries passed to Handle into CloudEvents per
+// spec.PayloadFormat, and sends them with client. It does not consume from
+// the RabbitMQ queue itself.
+type Adapter struct {
+	spec   sourcesv1alpha1.RabbitmqSourceSpec
+	source string
+	client cloudevents.Client
+}
+
+// New returns an Adapter that converts deliveries per spec.PayloadFormat
+// and sends the resulting events to sink with client.
+func New(spec sourcesv1alpha1.RabbitmqSourceSpec, source string, client cloudevents.Client) *Adapter {
+	return &Adapter{spec: spec, source: source, client: client}
+}
+
+// Handle converts d and sends the resulting CloudEvent, returning an error
+// if either step fails. The source's static PayloadFormat wins when set;
+// otherwise the format is resolved per-delivery from d's AMQP header and
+// content-type.
+func (a *Adapter) Handle(ctx context.Context, d Delivery) error {
+	format := converters.Format(a.spec.PayloadFormat)
+	if format == "" {
+		format = converters.ResolveFormat(d.ContentType, d.Headers)
+	}
+
+	converter, ok := converters.Lookup(format)
+	if !ok {
+		return fmt.Errorf("no converter registered for payload format %q", format)
+	}
+
+	event, err := converter.Convert(a.source, d.Body)
+	if err != nil {
+		return fmt.Errorf("converting delivery to a CloudEvent: %w", err)
+	}
+
+	if result := a.client.Send(ctx, event); cloudevents.IsUndelivered(result) {
+		return fmt.Errorf("sending event to sink: %w", result)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"knative.dev/eventing-rabbitmq/pkg/adapter/converters"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// recordingClient captures the last event it was asked to Send, so tests
+// can assert on what the Adapter produced without a real sink.
+type recordingClient struct {
+	cloudevents.Client
+	sent *cloudevents.Event
+}
+
+func (c *recordingClient) Send(ctx context.Context, event cloudevents.Event) protocolResult {
+	c.sent = &event
+	return cloudevents.ResultACK
+}
+
+// protocolResult mirrors the return type of cloudevents.Client.Send, kept
+// as an alias here so recordingClient can implement it without importing
+// the protocol package directly.
+type protocolResult = cloudevents.Result
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		name          string
+		payloadFormat string
+		wantErr       bool
+	}{
+		{name: "raw format", payloadFormat: "raw"},
+		{name: "defaults to raw when unset"},
+		{name: "unregistered format errors", payloadFormat: "xml", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &recordingClient{}
+			a := New(sourcesv1alpha1.RabbitmqSourceSpec{PayloadFormat: tc.payloadFormat}, "unittest", client)
+
+			err := a.Handle(context.Background(), Delivery{Body: []byte("hello rabbit")})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Handle() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && client.sent == nil {
+				t.Error("Handle() didn't send an event")
+			}
+		})
+	}
+}
+
+// TestHandleResolvesFormatPerDelivery covers sources with no static
+// PayloadFormat, where the format comes from the AMQP delivery itself
+// instead -- via converters.HeaderFormat if set, else a guess from
+// ContentType.
+func TestHandleResolvesFormatPerDelivery(t *testing.T) {
+	tests := []struct {
+		name            string
+		delivery        Delivery
+		wantContentType string
+	}{
+		{
+			name:            "content-type resolves binary-json",
+			delivery:        Delivery{Body: []byte(`{"hello":"rabbit"}`), ContentType: "application/json"},
+			wantContentType: "application/json",
+		},
+		{
+			name: "header format overrides content-type",
+			delivery: Delivery{
+				Body:        []byte("opaque"),
+				ContentType: "application/json",
+				Headers:     map[string]interface{}{converters.HeaderFormat: "raw"},
+			},
+			wantContentType: "application/octet-stream",
+		},
+		{
+			name:            "no header or content-type falls back to raw",
+			delivery:        Delivery{Body: []byte("opaque")},
+			wantContentType: "application/octet-stream",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &recordingClient{}
+			a := New(sourcesv1alpha1.RabbitmqSourceSpec{}, "unittest", client)
+
+			if err := a.Handle(context.Background(), tc.delivery); err != nil {
+				t.Fatalf("Handle() returned error: %v", err)
+			}
+			if client.sent == nil {
+				t.Fatal("Handle() didn't send an event")
+			}
+			if got := client.sent.DataContentType(); got != tc.wantContentType {
+				t.Errorf("DataContentType() = %q, want %q", got, tc.wantContentType)
+			}
+		})
+	}
+}
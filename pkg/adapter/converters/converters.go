@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package converters turns an AMQP message body into a CloudEvent for the
+// RabbitmqSource receive adapter to dispatch via cloudevents-sdk-go v2. The
+// wire representation to use is normally pinned by
+// RabbitmqSourceSpec.PayloadFormat; when that's unset, ResolveFormat picks
+// one per-delivery from the AMQP message's header or content-type instead.
+// Either way the Format is looked up in a package-level registry, so new
+// formats can be added without changing the adapter itself.
+package converters
+
+import (
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// EventType is the CloudEvents type set on events the receive adapter
+// produces with the raw and binary formats. FormatStructuredJSON events keep
+// the type from their own envelope.
+const EventType = "dev.knative.sources.rabbitmq.event"
+
+// Format names a wire representation for an AMQP message body.
+type Format string
+
+const (
+	// FormatRaw wraps the whole message body as opaque event data. This is
+	// the fallback when neither RabbitmqSourceSpec.PayloadFormat nor the
+	// delivery's header or content-type names a format.
+	FormatRaw Format = "raw"
+	// FormatStructuredJSON treats the message body as a structured-mode
+	// CloudEvents JSON envelope (attributes and data in one JSON document).
+	FormatStructuredJSON Format = "structured-json"
+	// FormatBinaryJSON treats the message body as a JSON-encoded data
+	// payload, to be wrapped with adapter-assigned CloudEvents attributes.
+	FormatBinaryJSON Format = "binary-json"
+	// FormatBinaryProtobuf treats the message body as an opaque protobuf
+	// payload, to be wrapped with adapter-assigned CloudEvents attributes.
+	FormatBinaryProtobuf Format = "binary-protobuf"
+)
+
+// Converter turns an AMQP message body into a CloudEvent.
+type Converter interface {
+	Convert(source string, body []byte) (cloudevents.Event, error)
+}
+
+var registry = map[Format]Converter{
+	FormatRaw:            rawConverter{},
+	FormatStructuredJSON: structuredJSONConverter{},
+	FormatBinaryJSON:     binaryConverter{contentType: "application/json"},
+	FormatBinaryProtobuf: binaryConverter{contentType: "application/protobuf"},
+}
+
+// HeaderFormat is the AMQP message header whose value, when set, names the
+// Format to use for that single delivery directly -- it takes precedence
+// over a guess from the message's content-type.
+const HeaderFormat = "x-knative-payload-format"
+
+// ResolveFormat picks the Format a single AMQP delivery should be converted
+// with, from headers[HeaderFormat] if set, else a guess from contentType.
+// It returns "" if neither yields a format, which Lookup treats as
+// FormatRaw.
+func ResolveFormat(contentType string, headers map[string]interface{}) Format {
+	if v, ok := headers[HeaderFormat]; ok {
+		if name, ok := v.(string); ok && name != "" {
+			return Format(name)
+		}
+	}
+
+	switch contentType {
+	case "application/cloudevents+json":
+		return FormatStructuredJSON
+	case "application/json":
+		return FormatBinaryJSON
+	case "application/protobuf":
+		return FormatBinaryProtobuf
+	default:
+		return ""
+	}
+}
+
+// Lookup returns the Converter registered for format, and whether one was
+// found. An empty format looks up FormatRaw.
+func Lookup(format Format) (Converter, bool) {
+	if format == "" {
+		format = FormatRaw
+	}
+	c, ok := registry[format]
+	return c, ok
+}
+
+// Register adds, or replaces, the Converter for format. It lets adapters
+// support a custom PayloadFormat beyond the built-ins above.
+func Register(format Format, c Converter) {
+	registry[format] = c
+}
+
+// rawConverter wraps the entire message body as opaque event data.
+type rawConverter struct{}
+
+func (rawConverter) Convert(source string, body []byte) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetType(EventType)
+	event.SetSource(source)
+	if err := event.SetData("application/octet-stream", body); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("setting raw event data: %w", err)
+	}
+	return event, nil
+}
+
+// structuredJSONConverter treats the message body as a structured-mode
+// CloudEvents JSON envelope.
+type structuredJSONConverter struct{}
+
+func (structuredJSONConverter) Convert(source string, body []byte) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	if err := event.UnmarshalJSON(body); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("parsing structured CloudEvents JSON: %w", err)
+	}
+	return event, nil
+}
+
+// binaryConverter wraps the message body as event data under contentType,
+// with CloudEvents attributes assigned by the adapter.
+type binaryConverter struct {
+	contentType string
+}
+
+func (c binaryConverter) Convert(source string, body []byte) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetType(EventType)
+	event.SetSource(source)
+	if err := event.SetData(c.contentType, body); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("setting %s event data: %w", c.contentType, err)
+	}
+	return event, nil
+}
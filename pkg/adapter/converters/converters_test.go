@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		wantOK bool
+	}{
+		{name: "empty format falls back to raw", format: "", wantOK: true},
+		{name: "raw", format: FormatRaw, wantOK: true},
+		{name: "structured-json", format: FormatStructuredJSON, wantOK: true},
+		{name: "binary-json", format: FormatBinaryJSON, wantOK: true},
+		{name: "binary-protobuf", format: FormatBinaryProtobuf, wantOK: true},
+		{name: "unregistered format", format: "xml", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok := Lookup(tc.format)
+			if ok != tc.wantOK {
+				t.Errorf("Lookup(%q) ok = %v, want %v", tc.format, ok, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestResolveFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		headers     map[string]interface{}
+		want        Format
+	}{
+		{name: "no content-type or header resolves nothing", want: ""},
+		{name: "cloudevents+json content-type", contentType: "application/cloudevents+json", want: FormatStructuredJSON},
+		{name: "json content-type", contentType: "application/json", want: FormatBinaryJSON},
+		{name: "protobuf content-type", contentType: "application/protobuf", want: FormatBinaryProtobuf},
+		{name: "unrecognized content-type resolves nothing", contentType: "text/plain", want: ""},
+		{
+			name:        "header overrides content-type",
+			contentType: "application/json",
+			headers:     map[string]interface{}{HeaderFormat: "raw"},
+			want:        FormatRaw,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolveFormat(tc.contentType, tc.headers); got != tc.want {
+				t.Errorf("ResolveFormat(%q, %v) = %q, want %q", tc.contentType, tc.headers, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRawConverterRoundTrip(t *testing.T) {
+	c := rawConverter{}
+	body := []byte("hello rabbit")
+
+	event, err := c.Convert("unittest", body)
+	if err != nil {
+		t.Fatalf("Convert() returned error: %v", err)
+	}
+	if event.Type() != EventType {
+		t.Errorf("Type() = %q, want %q", event.Type(), EventType)
+	}
+	if !bytes.Equal(event.Data(), body) {
+		t.Errorf("Data() = %q, want %q", event.Data(), body)
+	}
+}
+
+func TestStructuredJSONConverterRoundTrip(t *testing.T) {
+	c := structuredJSONConverter{}
+	body := []byte(`{
+		"specversion": "1.0",
+		"id": "abc-123",
+		"source": "unittest",
+		"type": "dev.knative.foo.bar",
+		"datacontenttype": "application/json",
+		"data": {"hello": "rabbit"}
+	}`)
+
+	event, err := c.Convert("unittest", body)
+	if err != nil {
+		t.Fatalf("Convert() returned error: %v", err)
+	}
+	if event.ID() != "abc-123" {
+		t.Errorf("ID() = %q, want %q", event.ID(), "abc-123")
+	}
+	if event.Type() != "dev.knative.foo.bar" {
+		t.Errorf("Type() = %q, want %q", event.Type(), "dev.knative.foo.bar")
+	}
+}
+
+func TestBinaryConverterRoundTrip(t *testing.T) {
+	c := binaryConverter{contentType: "application/json"}
+	body := []byte(`{"hello":"rabbit"}`)
+
+	event, err := c.Convert("unittest", body)
+	if err != nil {
+		t.Fatalf("Convert() returned error: %v", err)
+	}
+	if event.DataContentType() != "application/json" {
+		t.Errorf("DataContentType() = %q, want %q", event.DataContentType(), "application/json")
+	}
+	if !bytes.Equal(event.Data(), body) {
+		t.Errorf("Data() = %q, want %q", event.Data(), body)
+	}
+}
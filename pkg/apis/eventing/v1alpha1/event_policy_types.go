@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventPolicy gates which producers may deliver events to a RabbitTrigger
+// (or Broker), narrowing the set of sources a sink otherwise accepts from
+// anyone who can publish to it.
+type EventPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EventPolicySpec `json:"spec,omitempty"`
+	// +optional
+	Status EventPolicyStatus `json:"status,omitempty"`
+}
+
+// EventPolicySpec describes who is allowed to deliver events to To, and
+// under what additional filter constraints.
+type EventPolicySpec struct {
+	// To is the RabbitTrigger or Broker this policy applies to. A nil To
+	// means the policy applies to every RabbitTrigger backed by the
+	// RabbitMQ broker class in the policy's namespace.
+	// +optional
+	To *duckv1.KReference `json:"to,omitempty"`
+
+	// From lists the producers allowed to deliver events to To. An event
+	// must match at least one entry to be accepted.
+	From []EventPolicyFromSpec `json:"from,omitempty"`
+
+	// Filters further restricts accepted events beyond producer identity,
+	// using the same dialects as RabbitTriggerSpec.Filters.
+	// +optional
+	Filters []SubscriptionsAPIFilter `json:"filters,omitempty"`
+}
+
+// EventPolicyFromSpec identifies an allowed producer, either by reference
+// to a Kubernetes object or by its OIDC service-account identity. Exactly
+// one of Ref or OIDC must be set.
+type EventPolicyFromSpec struct {
+	// Ref points at the source object (e.g. a Broker or Source) allowed to
+	// deliver events.
+	// +optional
+	Ref *duckv1.KReference `json:"ref,omitempty"`
+
+	// OIDC identifies an allowed producer by the service account whose
+	// token it authenticates delivery requests with.
+	// +optional
+	OIDC *EventPolicyFromOIDC `json:"oidc,omitempty"`
+}
+
+// EventPolicyFromOIDC identifies a producer by Kubernetes service account.
+type EventPolicyFromOIDC struct {
+	// Namespace of the service account. Defaults to the EventPolicy's own
+	// namespace when unset.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ServiceAccount is the name of the service account whose OIDC token
+	// identifies the producer.
+	ServiceAccount string `json:"serviceAccount"`
+}
+
+// EventPolicyStatus represents the current state of an EventPolicy.
+type EventPolicyStatus struct {
+	duckv1.Status `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventPolicyList is a list of EventPolicy resources.
+type EventPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EventPolicy `json:"items"`
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+func (p *EventPolicy) Validate(ctx context.Context) *apis.FieldError {
+	return p.Spec.validate(ctx, []string{"spec"})
+}
+
+func (s *EventPolicySpec) validate(ctx context.Context, path []string) *apis.FieldError {
+	if len(s.From) == 0 {
+		return &apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   append(append([]string{}, path...), "from"),
+		}
+	}
+
+	var errs *apis.FieldError
+	for i, f := range s.From {
+		errs = errs.Also(f.validate(append(append([]string{}, path...), fmt.Sprintf("from[%d]", i))))
+	}
+
+	for i, filter := range s.Filters {
+		errs = errs.Also(filter.validate(append(append([]string{}, path...), fmt.Sprintf("filters[%d]", i))))
+	}
+
+	return errs
+}
+
+func (f *EventPolicyFromSpec) validate(path []string) *apis.FieldError {
+	switch {
+	case f.Ref == nil && f.OIDC == nil:
+		return &apis.FieldError{
+			Message: "expected exactly one, got neither",
+			Paths:   append(append([]string{}, path...), "ref", "oidc"),
+		}
+	case f.Ref != nil && f.OIDC != nil:
+		return &apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   append(append([]string{}, path...), "ref", "oidc"),
+		}
+	case f.OIDC != nil && f.OIDC.ServiceAccount == "":
+		return &apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   append(append([]string{}, path...), "oidc", "serviceAccount"),
+		}
+	}
+	return nil
+}
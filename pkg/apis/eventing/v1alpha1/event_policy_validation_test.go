@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestEventPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *v1.EventPolicy
+		err    *apis.FieldError
+	}{
+		{
+			name: "valid ref producer",
+			policy: &v1.EventPolicy{Spec: v1.EventPolicySpec{
+				From: []v1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Kind: "Broker", Name: "foo"}}},
+			}},
+		},
+		{
+			name: "valid cross-namespace ref producer",
+			policy: &v1.EventPolicy{Spec: v1.EventPolicySpec{
+				From: []v1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Kind: "Broker", Namespace: "other-ns", Name: "foo"}}},
+			}},
+		},
+		{
+			name: "valid oidc producer",
+			policy: &v1.EventPolicy{Spec: v1.EventPolicySpec{
+				From: []v1.EventPolicyFromSpec{{OIDC: &v1.EventPolicyFromOIDC{ServiceAccount: "producer"}}},
+			}},
+		},
+		{
+			name:   "missing from",
+			policy: &v1.EventPolicy{},
+			err: &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"spec", "from"},
+			},
+		},
+		{
+			name: "from with neither ref nor oidc",
+			policy: &v1.EventPolicy{Spec: v1.EventPolicySpec{
+				From: []v1.EventPolicyFromSpec{{}},
+			}},
+			err: &apis.FieldError{
+				Message: "expected exactly one, got neither",
+				Paths:   []string{"spec", "from[0]", "ref", "oidc"},
+			},
+		},
+		{
+			name: "from with both ref and oidc",
+			policy: &v1.EventPolicy{Spec: v1.EventPolicySpec{
+				From: []v1.EventPolicyFromSpec{{
+					Ref:  &duckv1.KReference{Kind: "Broker", Name: "foo"},
+					OIDC: &v1.EventPolicyFromOIDC{ServiceAccount: "producer"},
+				}},
+			}},
+			err: &apis.FieldError{
+				Message: "expected exactly one, got both",
+				Paths:   []string{"spec", "from[0]", "ref", "oidc"},
+			},
+		},
+		{
+			name: "oidc missing service account",
+			policy: &v1.EventPolicy{Spec: v1.EventPolicySpec{
+				From: []v1.EventPolicyFromSpec{{OIDC: &v1.EventPolicyFromOIDC{}}},
+			}},
+			err: &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"spec", "from[0]", "oidc", "serviceAccount"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate(context.Background())
+			if diff := cmp.Diff(tc.err, err, cmpopts.IgnoreUnexported(apis.FieldError{})); diff != "" {
+				t.Error("EventPolicy.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
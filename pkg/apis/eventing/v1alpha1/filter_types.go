@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// SubscriptionsAPIFilter is a single dialect of the CloudEvents
+// subscriptions-API filter spec. Exactly one of its fields may be set.
+type SubscriptionsAPIFilter struct {
+	// Exact matches CE attributes whose value equals the given string.
+	// +optional
+	Exact map[string]string `json:"exact,omitempty"`
+
+	// Prefix matches CE attributes whose value has the given prefix.
+	// +optional
+	Prefix map[string]string `json:"prefix,omitempty"`
+
+	// Suffix matches CE attributes whose value has the given suffix.
+	// +optional
+	Suffix map[string]string `json:"suffix,omitempty"`
+
+	// All requires every nested filter to match.
+	// +optional
+	All []SubscriptionsAPIFilter `json:"all,omitempty"`
+
+	// Any requires at least one nested filter to match.
+	// +optional
+	Any []SubscriptionsAPIFilter `json:"any,omitempty"`
+
+	// Not inverts the nested filter's result.
+	// +optional
+	Not *SubscriptionsAPIFilter `json:"not,omitempty"`
+
+	// CESQL is a CloudEvents SQL expression evaluated against the event.
+	// +optional
+	CESQL string `json:"cesql,omitempty"`
+}
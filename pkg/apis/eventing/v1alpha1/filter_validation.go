@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	cesql "github.com/cloudevents/sdk-go/sql/v2"
+	"knative.dev/pkg/apis"
+)
+
+// validateFilters enforces that Filter and Filters aren't both set, that
+// each entry in Filters picks exactly one dialect, and that any cesql
+// expressions compile.
+func (t *RabbitTrigger) validateFilters() *apis.FieldError {
+	if t.Spec.Filter != nil && len(t.Spec.Filters) > 0 {
+		return apis.ErrMultipleOneOf("filter", "filters").ViaField("spec")
+	}
+
+	var errs *apis.FieldError
+	for i, f := range t.Spec.Filters {
+		errs = errs.Also(f.validate([]string{"spec", fmt.Sprintf("filters[%d]", i)}))
+	}
+	return errs
+}
+
+// validate checks that exactly one dialect is set on f, compiling the
+// cesql dialect up front so a malformed expression is rejected at
+// admission time rather than at delivery time. path is the field path of f
+// itself, e.g. []string{"spec", "filters[0]"}.
+func (f *SubscriptionsAPIFilter) validate(path []string) *apis.FieldError {
+	set := 0
+	if f.Exact != nil {
+		set++
+	}
+	if f.Prefix != nil {
+		set++
+	}
+	if f.Suffix != nil {
+		set++
+	}
+	if f.All != nil {
+		set++
+	}
+	if f.Any != nil {
+		set++
+	}
+	if f.Not != nil {
+		set++
+	}
+	if f.CESQL != "" {
+		set++
+	}
+
+	if set != 1 {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("exactly one filter dialect must be set, got %d", set),
+			Paths:   path,
+		}
+	}
+
+	var errs *apis.FieldError
+	if f.CESQL != "" {
+		if _, err := cesql.Parse(f.CESQL); err != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "Failed to parse cesql expression",
+				Paths:   append(append([]string{}, path...), "cesql"),
+				Details: err.Error(),
+			})
+		}
+	}
+
+	for i, nested := range f.All {
+		errs = errs.Also(nested.validate(append(append([]string{}, path...), fmt.Sprintf("all[%d]", i))))
+	}
+	for i, nested := range f.Any {
+		errs = errs.Also(nested.validate(append(append([]string{}, path...), fmt.Sprintf("any[%d]", i))))
+	}
+	if f.Not != nil {
+		errs = errs.Also(f.Not.validate(append(append([]string{}, path...), "not")))
+	}
+
+	return errs
+}
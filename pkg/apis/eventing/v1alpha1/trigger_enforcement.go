@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+// EnforcementAction controls how a RabbitMQ-specific annotation violation
+// is surfaced at admission time.
+type EnforcementAction string
+
+const (
+	// EnforcementActionDeny fails admission, as every annotation violation
+	// did before scoped enforcement existed.
+	EnforcementActionDeny EnforcementAction = "deny"
+	// EnforcementActionWarn admits the request but attaches a warning.
+	EnforcementActionWarn EnforcementAction = "warn"
+	// EnforcementActionDryRun only logs the violation; admission is
+	// unaffected.
+	EnforcementActionDryRun EnforcementAction = "dryrun"
+)
+
+// enforcementActionAnnotation lets an individual RabbitTrigger opt into a
+// less strict enforcement action than the cluster default, for every
+// RabbitMQ annotation violation that isn't otherwise scoped by
+// EnforcementConfig.PerAnnotation.
+const enforcementActionAnnotation = "rabbitmq.eventing.knative.dev/enforcement-action"
+
+// EnforcementConfig is the cluster-wide scoped-enforcement configuration.
+// It's sourced from a ConfigMap by the webhook and stashed into the
+// admission context via WithEnforcementConfig.
+type EnforcementConfig struct {
+	// DefaultAction applies to any violation without a more specific
+	// override. Defaults to EnforcementActionDeny when empty.
+	DefaultAction EnforcementAction
+
+	// PerAnnotation overrides DefaultAction, and any per-trigger
+	// enforcement-action annotation, for violations of a specific
+	// RabbitMQ annotation. For example, an operator can hard-deny invalid
+	// CPU quantities while only warning on out-of-bounds parallelism:
+	//
+	//   PerAnnotation: map[string]EnforcementAction{
+	//       utils.CPURequestAnnotation: EnforcementActionDeny,
+	//       parallelismAnnotation:      EnforcementActionWarn,
+	//   }
+	PerAnnotation map[string]EnforcementAction
+}
+
+// actionFor resolves the effective action for a violation of annotation,
+// given the requesting trigger's own enforcement-action annotation.
+// PerAnnotation wins over the per-trigger annotation, which wins over
+// DefaultAction, which defaults to deny.
+func (c EnforcementConfig) actionFor(annotation string, triggerAction EnforcementAction) EnforcementAction {
+	if a, ok := c.PerAnnotation[annotation]; ok && a != "" {
+		return a
+	}
+	if triggerAction != "" {
+		return triggerAction
+	}
+	if c.DefaultAction != "" {
+		return c.DefaultAction
+	}
+	return EnforcementActionDeny
+}
+
+// enforcementConfigKey is the context key the webhook stashes the cluster
+// EnforcementConfig ConfigMap under.
+type enforcementConfigKey struct{}
+
+// WithEnforcementConfig stashes cfg into ctx for Validate to consult.
+func WithEnforcementConfig(ctx context.Context, cfg EnforcementConfig) context.Context {
+	return context.WithValue(ctx, enforcementConfigKey{}, cfg)
+}
+
+// GetEnforcementConfig returns the config stashed by WithEnforcementConfig,
+// or the zero value (global deny, no overrides) if none was stashed.
+func GetEnforcementConfig(ctx context.Context) EnforcementConfig {
+	cfg, _ := ctx.Value(enforcementConfigKey{}).(EnforcementConfig)
+	return cfg
+}
+
+// annotationViolation pairs a validation failure with the RabbitMQ
+// annotation that produced it, so route can look up its scoped action.
+type annotationViolation struct {
+	annotation string
+	err        *apis.FieldError
+}
+
+// route applies the cluster EnforcementConfig and this trigger's own
+// enforcement-action annotation to each violation: deny violations block
+// admission as a normal FieldError, warn violations are returned marked as
+// warnings via apis.FieldError's warning API, and dryrun violations are
+// only logged.
+func (t *RabbitTrigger) route(ctx context.Context, violations []annotationViolation) *apis.FieldError {
+	cfg := GetEnforcementConfig(ctx)
+	triggerAction := EnforcementAction(t.GetAnnotations()[enforcementActionAnnotation])
+
+	var errs *apis.FieldError
+	for _, v := range violations {
+		switch cfg.actionFor(v.annotation, triggerAction) {
+		case EnforcementActionWarn:
+			errs = errs.Also(v.err.MarkAsWarning())
+		case EnforcementActionDryRun:
+			logging.FromContext(ctx).Warnf("dryrun enforcement for RabbitTrigger %s/%s: %s", t.Namespace, t.Name, v.err.Error())
+		default:
+			errs = errs.Also(v.err)
+		}
+	}
+	return errs
+}
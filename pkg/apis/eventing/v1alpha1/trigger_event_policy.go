@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+)
+
+// eventPoliciesKey is the context key the webhook stashes the EventPolicy
+// objects applicable to the namespace being admitted under, so Validate
+// doesn't have to go list them itself (there's no informer available from
+// inside the webhook's Validate call).
+type eventPoliciesKey struct{}
+
+// WithEventPolicies returns a context carrying policies, for Validate to
+// consult via GetEventPolicies.
+func WithEventPolicies(ctx context.Context, policies []*EventPolicy) context.Context {
+	return context.WithValue(ctx, eventPoliciesKey{}, policies)
+}
+
+// GetEventPolicies returns the EventPolicy objects stashed by WithEventPolicies,
+// or nil if none were.
+func GetEventPolicies(ctx context.Context) []*EventPolicy {
+	policies, _ := ctx.Value(eventPoliciesKey{}).([]*EventPolicy)
+	return policies
+}
+
+// validateEventPolicies rejects Trigger configurations that would leave an
+// applicable EventPolicy's exact-dialect Filters unsatisfiable by the
+// Trigger's own legacy attribute filter; filters of the other dialects are
+// not compared. It only catches that one admission-time
+// contradiction -- an EventPolicy's From producer-identity gating can't be
+// evaluated here, since admission time has no delivery to check it
+// against; that's enforced at delivery time instead, by
+// pkg/dispatcher/eventpolicy.Authorized, which every dispatcher consults
+// before acking a message.
+func (t *RabbitTrigger) validateEventPolicies(ctx context.Context, broker *eventingv1.Broker) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range GetEventPolicies(ctx) {
+		if !p.AppliesTo(t.Namespace, t.Name, broker) || len(p.Spec.Filters) == 0 {
+			continue
+		}
+
+		for _, f := range p.Spec.Filters {
+			if f.Exact == nil {
+				continue
+			}
+			if !t.satisfiesExactFilter(f.Exact) {
+				errs = errs.Also(&apis.FieldError{
+					Message: fmt.Sprintf("spec.filter is incompatible with EventPolicy %q, which requires attributes %v", p.Name, f.Exact),
+					Paths:   []string{"spec", "filter"},
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// AppliesTo reports whether p governs delivery to the RabbitTrigger named
+// triggerName in triggerNamespace, backed by broker. A nil broker, or one
+// not backed by the RabbitMQ broker class, never matches -- p.Spec.To's
+// "every RabbitTrigger backed by the RabbitMQ broker class" default only
+// means something once we know the Trigger's Broker actually is one.
+// Otherwise, p.Spec.To either isn't set (applies to every such RabbitTrigger
+// in the policy's own namespace), names broker (when its Kind is Broker), or
+// names this trigger directly.
+func (p *EventPolicy) AppliesTo(triggerNamespace, triggerName string, broker *eventingv1.Broker) bool {
+	if broker == nil || broker.Annotations[eventingv1.BrokerClassAnnotationKey] != BrokerClass {
+		return false
+	}
+	if p.Namespace != "" && p.Namespace != triggerNamespace {
+		return false
+	}
+	if p.Spec.To == nil {
+		return true
+	}
+	if p.Spec.To.Kind == "Broker" {
+		return p.Spec.To.Name == broker.Name
+	}
+	return p.Spec.To.Name == triggerName
+}
+
+// satisfiesExactFilter reports whether the Trigger's legacy attribute
+// filter is compatible with want, i.e. it doesn't pin any key in want to a
+// different value. A key want cares about that the Trigger's filter
+// doesn't mention -- including the case where the Trigger has no filter at
+// all -- is compatible: an unfiltered (or less-filtered) Trigger is a
+// superset of what want requires, not a contradiction of it.
+func (t *RabbitTrigger) satisfiesExactFilter(want map[string]string) bool {
+	if t.Spec.Filter == nil {
+		return true
+	}
+	for k, wantValue := range want {
+		if have, ok := t.Spec.Filter.Attributes[k]; ok && have != wantValue {
+			return false
+		}
+	}
+	return true
+}
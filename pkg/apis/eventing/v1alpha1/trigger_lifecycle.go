@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+// RabbitTriggerConditionProbeSucceeded is True when the controller's most
+// recent synthetic probe CloudEvent (see pkg/dispatcher/probe) round-tripped
+// through this Trigger's dispatcher successfully. It's a dependent
+// condition of Ready: a dispatcher that's stopped actually delivering
+// events shouldn't read as Ready just because its Deployment is up.
+const RabbitTriggerConditionProbeSucceeded apis.ConditionType = "ProbeSucceeded"
+
+// ProbeFailedReason is the Reason set on RabbitTriggerConditionProbeSucceeded,
+// and thus on Ready, when a probe event wasn't acked before the controller
+// gave up waiting for it.
+const ProbeFailedReason = "ProbeFailed"
+
+var triggerConditionSet = apis.NewLivingConditionSet(RabbitTriggerConditionProbeSucceeded)
+
+// MarkProbeSucceeded records that the controller's most recent dispatcher
+// probe completed successfully.
+func (t *RabbitTrigger) MarkProbeSucceeded() {
+	triggerConditionSet.Manage(&t.Status).MarkTrue(RabbitTriggerConditionProbeSucceeded)
+}
+
+// MarkProbeFailed records that the dispatcher didn't ack a probe
+// CloudEvent within its deadline, detailing why in message.
+func (t *RabbitTrigger) MarkProbeFailed(message string) {
+	triggerConditionSet.Manage(&t.Status).MarkFalse(RabbitTriggerConditionProbeSucceeded, ProbeFailedReason, message)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+func TestMarkProbe(t *testing.T) {
+	tr := &v1.RabbitTrigger{}
+
+	tr.MarkProbeFailed("dispatcher didn't ack in time")
+	if got := tr.Status.GetCondition(v1.RabbitTriggerConditionProbeSucceeded); got == nil || got.Status != corev1.ConditionFalse {
+		t.Fatalf("ProbeSucceeded condition = %+v, want False", got)
+	}
+	if got := tr.Status.GetCondition(apis.ConditionReady); got == nil || got.Status != corev1.ConditionFalse || got.Reason != v1.ProbeFailedReason {
+		t.Fatalf("Ready condition = %+v, want False with reason %q", got, v1.ProbeFailedReason)
+	}
+
+	tr.MarkProbeSucceeded()
+	if got := tr.Status.GetCondition(v1.RabbitTriggerConditionProbeSucceeded); got == nil || got.Status != corev1.ConditionTrue {
+		t.Fatalf("ProbeSucceeded condition = %+v, want True", got)
+	}
+	if got := tr.Status.GetCondition(apis.ConditionReady); got == nil || got.Status != corev1.ConditionTrue {
+		t.Fatalf("Ready condition = %+v, want True", got)
+	}
+}
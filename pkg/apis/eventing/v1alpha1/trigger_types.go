@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitTrigger represents a Trigger that is bound to a RabbitMQ-backed
+// Broker. It carries the same registration semantics as the core Knative
+// Eventing Trigger, plus fields that only make sense for the RabbitMQ
+// broker implementation (e.g. cross-namespace broker references).
+type RabbitTrigger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the RabbitTrigger.
+	Spec RabbitTriggerSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the RabbitTrigger.
+	// +optional
+	Status eventingv1.TriggerStatus `json:"status,omitempty"`
+}
+
+// RabbitTriggerSpec extends the core eventing TriggerSpec with fields that
+// are specific to the RabbitMQ broker implementation.
+type RabbitTriggerSpec struct {
+	eventingv1.TriggerSpec `json:",inline"`
+
+	// BrokerRef points to the Broker this Trigger receives events from. It
+	// may reference a Broker in a different namespace than the Trigger,
+	// unlike the legacy Broker field. Exactly one of Broker or BrokerRef
+	// may be set.
+	// +optional
+	BrokerRef *duckv1.KReference `json:"brokerRef,omitempty"`
+
+	// Filters replaces the legacy Filter.Attributes exact-match map with
+	// the CE subscriptions-API filter dialects (exact, prefix, suffix, all,
+	// any, not, cesql). Events are delivered when every filter in the list
+	// matches. Filters and Filter are mutually exclusive.
+	// +optional
+	Filters []SubscriptionsAPIFilter `json:"filters,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitTriggerList is a list of RabbitTrigger resources.
+type RabbitTriggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RabbitTrigger `json:"items"`
+}
+
+// GetCrossNamespaceRef resolves the Broker this RabbitTrigger targets into a
+// duckv1.KReference, regardless of whether it was set via the legacy
+// in-namespace Broker field or the newer cross-namespace BrokerRef. Callers
+// (reconcilers, the webhook) should use this instead of reading Spec.Broker
+// or Spec.BrokerRef directly so that both forms are handled uniformly.
+func (t *RabbitTrigger) GetCrossNamespaceRef() duckv1.KReference {
+	if t.Spec.BrokerRef != nil {
+		ref := *t.Spec.BrokerRef
+		if ref.Namespace == "" {
+			ref.Namespace = t.Namespace
+		}
+		if ref.Kind == "" {
+			ref.Kind = "Broker"
+		}
+		if ref.APIVersion == "" {
+			ref.APIVersion = eventingv1.SchemeGroupVersion.String()
+		}
+		return ref
+	}
+
+	return duckv1.KReference{
+		APIVersion: eventingv1.SchemeGroupVersion.String(),
+		Kind:       "Broker",
+		Namespace:  t.Namespace,
+		Name:       t.Spec.Broker,
+	}
+}
+
+// GetGroupVersionKind returns the GroupVersionKind for RabbitTrigger.
+func (t *RabbitTrigger) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("RabbitTrigger")
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/client/injection/client"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmp"
+
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+
+	"knative.dev/eventing-rabbitmq/pkg/utils"
+)
+
+// BrokerClass is the value of the eventing.knative.dev/broker.class
+// annotation that marks a Broker as backed by RabbitMQ, and therefore a
+// valid target for a RabbitTrigger.
+const BrokerClass = "RabbitMQBroker"
+
+// parallelismAnnotation controls how many dispatcher replicas a single
+// RabbitTrigger gets.
+const parallelismAnnotation = "rabbitmq.eventing.knative.dev/parallelism"
+
+const (
+	minParallelism = 1
+	maxParallelism = 1000
+)
+
+// probeIntervalAnnotation controls how often the controller publishes a
+// synthetic probe CloudEvent through this Trigger's dispatcher to confirm
+// it's still delivering end-to-end. See pkg/dispatcher/probe and
+// trigger_lifecycle.go.
+const probeIntervalAnnotation = "rabbitmq.eventing.knative.dev/probe-interval"
+
+const (
+	minProbeInterval = 10 * time.Second
+	maxProbeInterval = 1 * time.Hour
+)
+
+// knsubscribeVerb is the authorization verb checked via SubjectAccessReview
+// before a RabbitTrigger is allowed to subscribe to a Broker in another
+// namespace.
+const knsubscribeVerb = "knsubscribe"
+
+func (t *RabbitTrigger) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if apis.IsInUpdate(ctx) {
+		if original, ok := apis.GetBaseline(ctx).(*eventingv1.Trigger); ok {
+			errs = errs.Also(t.CheckImmutableFields(ctx, original))
+		}
+	}
+
+	errs = errs.Also(t.validateBrokerRef(ctx))
+	errs = errs.Also(t.validateFilters())
+
+	broker, ok := t.getRabbitMQBroker(ctx)
+	if !ok {
+		// Either the Broker doesn't exist yet, or it isn't ours -- the
+		// RabbitMQ-specific checks below (EventPolicy contradictions,
+		// annotations) only make sense once we know we're actually backing
+		// this Trigger.
+		return errs
+	}
+
+	errs = errs.Also(t.validateEventPolicies(ctx, broker))
+	return errs.Also(t.validateAnnotations(ctx))
+}
+
+// CheckImmutableFields ensures that fields which cannot be changed after
+// creation (today, just the legacy attribute filter) haven't been.
+func (t *RabbitTrigger) CheckImmutableFields(ctx context.Context, original *eventingv1.Trigger) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	if diff, err := kmp.ShortDiff(original.Spec.Filter, t.Spec.Filter); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff Trigger",
+			Paths:   []string{"spec", "filter"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec", "filter"},
+			Details: diff,
+		}
+	}
+
+	return nil
+}
+
+// validateBrokerRef ensures Broker and BrokerRef aren't both set, and that
+// cross-namespace references are authorized via a SubjectAccessReview.
+func (t *RabbitTrigger) validateBrokerRef(ctx context.Context) *apis.FieldError {
+	if t.Spec.Broker != "" && t.Spec.BrokerRef != nil {
+		return apis.ErrMultipleOneOf("broker", "brokerRef").ViaField("spec")
+	}
+
+	if t.Spec.BrokerRef == nil {
+		return nil
+	}
+
+	ref := t.GetCrossNamespaceRef()
+	if ref.Namespace == "" || ref.Namespace == t.Namespace {
+		return nil
+	}
+
+	return t.authorizeCrossNamespaceRef(ctx, ref)
+}
+
+// authorizeCrossNamespaceRef performs a SubjectAccessReview, on behalf of
+// the user making this admission request, to confirm they're allowed to
+// subscribe a trigger to Brokers in ref.Namespace.
+func (t *RabbitTrigger) authorizeCrossNamespaceRef(ctx context.Context, ref duckv1.KReference) *apis.FieldError {
+	userInfo := apis.GetUserInfo(ctx)
+	if userInfo == nil {
+		return &apis.FieldError{
+			Message: "unable to determine requesting user for cross-namespace broker reference",
+			Paths:   []string{"spec", "brokerRef"},
+		}
+	}
+
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: ref.Namespace,
+				Verb:      knsubscribeVerb,
+				Group:     eventingv1.SchemeGroupVersion.Group,
+				Resource:  "brokers",
+				Name:      ref.Name,
+			},
+			User:   userInfo.Username,
+			UID:    userInfo.UID,
+			Groups: userInfo.Groups,
+		},
+	}
+
+	resp, err := kubeclient.Get(ctx).AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return &apis.FieldError{
+			Message: "failed to perform SubjectAccessReview for cross-namespace broker reference",
+			Paths:   []string{"spec", "brokerRef"},
+			Details: err.Error(),
+		}
+	}
+
+	if !resp.Status.Allowed {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("user %q is not authorized to subscribe triggers to brokers in namespace %q", userInfo.Username, ref.Namespace),
+			Paths:   []string{"spec", "brokerRef"},
+		}
+	}
+
+	return nil
+}
+
+// getRabbitMQBroker looks up the Broker this RabbitTrigger targets and
+// reports whether it exists and is backed by the RabbitMQ broker class.
+func (t *RabbitTrigger) getRabbitMQBroker(ctx context.Context) (*eventingv1.Broker, bool) {
+	ref := t.GetCrossNamespaceRef()
+
+	b, err := client.Get(ctx).EventingV1().Brokers(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil || b == nil {
+		return nil, false
+	}
+
+	if b.Annotations[eventingv1.BrokerClassAnnotationKey] != BrokerClass {
+		return nil, false
+	}
+
+	return b, true
+}
+
+// validateAnnotations checks the RabbitMQ-specific annotations that tune
+// the generated dispatcher Deployment for this Trigger. Violations are
+// routed through the cluster EnforcementConfig (and this trigger's own
+// enforcement-action annotation) rather than failing admission outright --
+// see trigger_enforcement.go.
+func (t *RabbitTrigger) validateAnnotations(ctx context.Context) *apis.FieldError {
+	annotations := t.GetAnnotations()
+	var violations []annotationViolation
+
+	if raw, ok := annotations[parallelismAnnotation]; ok {
+		if err := validateParallelismAnnotation(raw); err != nil {
+			violations = append(violations, annotationViolation{parallelismAnnotation, err})
+		}
+	}
+
+	if raw, ok := annotations[utils.CPURequestAnnotation]; ok {
+		if err := validateCPURequestAnnotation(raw); err != nil {
+			violations = append(violations, annotationViolation{utils.CPURequestAnnotation, err})
+		}
+	}
+
+	if raw, ok := annotations[probeIntervalAnnotation]; ok {
+		if err := validateProbeIntervalAnnotation(raw); err != nil {
+			violations = append(violations, annotationViolation{probeIntervalAnnotation, err})
+		}
+	}
+
+	return t.route(ctx, violations)
+}
+
+func validateParallelismAnnotation(raw string) *apis.FieldError {
+	p, err := strconv.Atoi(raw)
+	if err != nil {
+		return &apis.FieldError{
+			Message: "Failed to parse valid int from parallelismAnnotation",
+			Paths:   []string{"metadata", "annotations", parallelismAnnotation},
+			Details: err.Error(),
+		}
+	}
+	if p < minParallelism || p > maxParallelism {
+		return apis.ErrOutOfBoundsValue(p, minParallelism, maxParallelism, parallelismAnnotation)
+	}
+	return nil
+}
+
+func validateProbeIntervalAnnotation(raw string) *apis.FieldError {
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return &apis.FieldError{
+			Message: "Failed to parse valid duration from probeIntervalAnnotation",
+			Paths:   []string{"metadata", "annotations", probeIntervalAnnotation},
+			Details: err.Error(),
+		}
+	}
+	if d < minProbeInterval || d > maxProbeInterval {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("expected probe-interval between %s and %s, got: %s", minProbeInterval, maxProbeInterval, d),
+			Paths:   []string{"metadata", "annotations", probeIntervalAnnotation},
+		}
+	}
+	return nil
+}
+
+func validateCPURequestAnnotation(raw string) *apis.FieldError {
+	if _, err := resource.ParseQuantity(raw); err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("Failed to parse quantity from %s", utils.CPURequestAnnotation),
+			Paths:   []string{"metadata", "annotations", utils.CPURequestAnnotation},
+			Details: err.Error(),
+		}
+	}
+	return nil
+}
@@ -20,27 +20,40 @@ import (
 	"context"
 	"testing"
 
+	cesql "github.com/cloudevents/sdk-go/sql/v2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
 	v1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
 	"knative.dev/eventing-rabbitmq/pkg/utils"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/client/clientset/versioned/fake"
 	"knative.dev/eventing/pkg/client/injection/client"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 )
 
 const parallelismAnnotation = "rabbitmq.eventing.knative.dev/parallelism"
+const probeIntervalAnnotation = "rabbitmq.eventing.knative.dev/probe-interval"
 
 func TestTriggerValidate(t *testing.T) {
 	tests := []struct {
-		name     string
-		trigger  *v1.RabbitTrigger
-		original *v1.RabbitTrigger
-		err      *apis.FieldError
-		objects  []runtime.Object
+		name        string
+		trigger     *v1.RabbitTrigger
+		original    *v1.RabbitTrigger
+		err         *apis.FieldError
+		warn        *apis.FieldError
+		enforcement v1.EnforcementConfig
+		objects     []runtime.Object
+		noUserInfo  bool
+		sarAllowed  bool
+		policies    []*v1.EventPolicy
 	}{
 		{
 			name:    "broker not found gets ignored",
@@ -120,24 +133,242 @@ func TestTriggerValidate(t *testing.T) {
 				validBroker("foo"),
 			},
 		},
+		{
+			name:    "invalid probe-interval annotation",
+			trigger: trigger(withBroker("foo"), withAnnotation(probeIntervalAnnotation, "not-a-duration")),
+			err: &apis.FieldError{
+				Message: "Failed to parse valid duration from probeIntervalAnnotation",
+				Paths:   []string{"metadata", "annotations", probeIntervalAnnotation},
+				Details: `time: invalid duration "not-a-duration"`,
+			},
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name:    "out of range probe-interval annotation",
+			trigger: trigger(withBroker("foo"), withAnnotation(probeIntervalAnnotation, "1s")),
+			err: &apis.FieldError{
+				Message: "expected probe-interval between 10s and 1h0m0s, got: 1s",
+				Paths:   []string{"metadata", "annotations", probeIntervalAnnotation},
+			},
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name:    "valid probe-interval annotation",
+			trigger: trigger(withBroker("foo"), withAnnotation(probeIntervalAnnotation, "30s")),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name:    "broker and brokerRef both set",
+			trigger: trigger(withBroker("foo"), withBrokerRef("other-ns", "foo")),
+			err:     apis.ErrMultipleOneOf("broker", "brokerRef").ViaField("spec"),
+		},
+		{
+			name:       "cross-namespace brokerRef without requesting user",
+			trigger:    trigger(withBrokerRef("other-ns", "foo")),
+			noUserInfo: true,
+			err: &apis.FieldError{
+				Message: "unable to determine requesting user for cross-namespace broker reference",
+				Paths:   []string{"spec", "brokerRef"},
+			},
+		},
+		{
+			name:       "cross-namespace brokerRef denied by SubjectAccessReview",
+			trigger:    trigger(withBrokerRef("other-ns", "foo")),
+			sarAllowed: false,
+			err: &apis.FieldError{
+				Message: `user "jane" is not authorized to subscribe triggers to brokers in namespace "other-ns"`,
+				Paths:   []string{"spec", "brokerRef"},
+			},
+		},
+		{
+			name:       "cross-namespace brokerRef allowed by SubjectAccessReview",
+			trigger:    trigger(withBrokerRef("other-ns", "foo")),
+			sarAllowed: true,
+			objects: []runtime.Object{
+				validBrokerInNamespace("other-ns", "foo"),
+			},
+		},
+		{
+			name: "legacy filter and subscriptions-API filters both set",
+			trigger: trigger(withBroker("foo"), withFilters(filter("x", "y")),
+				withSubsFilters(v1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "foo"}})),
+			err: apis.ErrMultipleOneOf("filter", "filters").ViaField("spec"),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name: "subscriptions-API filter with more than one dialect",
+			trigger: trigger(withBroker("foo"), withSubsFilters(v1.SubscriptionsAPIFilter{
+				Exact:  map[string]string{"type": "foo"},
+				Prefix: map[string]string{"type": "foo"},
+			})),
+			err: &apis.FieldError{
+				Message: "exactly one filter dialect must be set, got 2",
+				Paths:   []string{"spec", "filters[0]"},
+			},
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name: "subscriptions-API cesql filter fails to parse",
+			trigger: trigger(withBroker("foo"), withSubsFilters(v1.SubscriptionsAPIFilter{
+				CESQL: "this is not valid cesql (((",
+			})),
+			err: &apis.FieldError{
+				Message: "Failed to parse cesql expression",
+				Paths:   []string{"spec", "filters[0]", "cesql"},
+				Details: cesqlParseErr("this is not valid cesql (((").Error(),
+			},
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name: "valid subscriptions-API filters",
+			trigger: trigger(withBroker("foo"), withSubsFilters(v1.SubscriptionsAPIFilter{
+				Exact: map[string]string{"type": "dev.knative.foo"},
+			})),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name:    "satisfies applicable EventPolicy's filter requirement",
+			trigger: trigger(withBroker("foo"), withFilters(filter("type", "dev.knative.foo"))),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+			policies: []*v1.EventPolicy{
+				eventPolicy("policy", v1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "dev.knative.foo"}}),
+			},
+		},
+		{
+			name:    "applicable EventPolicy with a cross-namespace From ref doesn't block the trigger",
+			trigger: trigger(withBroker("foo"), withFilters(filter("type", "dev.knative.foo"))),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+			policies: []*v1.EventPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "cross-ns-policy"},
+					Spec: v1.EventPolicySpec{
+						From: []v1.EventPolicyFromSpec{{Ref: &duckv1.KReference{
+							Kind: "Broker", Namespace: "producer-ns", Name: "other-broker",
+						}}},
+						Filters: []v1.SubscriptionsAPIFilter{
+							{Exact: map[string]string{"type": "dev.knative.foo"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "unfiltered trigger is compatible with applicable EventPolicy's filter requirement",
+			trigger: trigger(withBroker("foo")),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+			policies: []*v1.EventPolicy{
+				eventPolicy("policy", v1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "dev.knative.foo"}}),
+			},
+		},
+		{
+			name:    "contradicts applicable EventPolicy's filter requirement",
+			trigger: trigger(withBroker("foo"), withFilters(filter("type", "dev.knative.bar"))),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+			policies: []*v1.EventPolicy{
+				eventPolicy("policy", v1.SubscriptionsAPIFilter{Exact: map[string]string{"type": "dev.knative.foo"}}),
+			},
+			err: &apis.FieldError{
+				Message: `spec.filter is incompatible with EventPolicy "policy", which requires attributes map[type:dev.knative.foo]`,
+				Paths:   []string{"spec", "filter"},
+			},
+		},
+		{
+			name:    "out of bounds parallelism warns instead of denying when the cluster scopes it to warn",
+			trigger: trigger(withBroker("foo"), withParallelism("0")),
+			enforcement: v1.EnforcementConfig{
+				PerAnnotation: map[string]v1.EnforcementAction{parallelismAnnotation: v1.EnforcementActionWarn},
+			},
+			warn: apis.ErrOutOfBoundsValue(0, 1, 1000, parallelismAnnotation),
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name:    "invalid resource annotation is only logged when the cluster scopes it to dryrun",
+			trigger: trigger(withBroker("foo"), withAnnotation(utils.CPURequestAnnotation, "invalid")),
+			enforcement: v1.EnforcementConfig{
+				DefaultAction: v1.EnforcementActionDryRun,
+			},
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
+		{
+			name: "per-trigger enforcement-action annotation warns by default, but an explicit PerAnnotation scope still denies",
+			trigger: trigger(withBroker("foo"), withParallelism("0"),
+				withAnnotation(utils.CPURequestAnnotation, "invalid"),
+				withAnnotation("rabbitmq.eventing.knative.dev/enforcement-action", "warn")),
+			enforcement: v1.EnforcementConfig{
+				PerAnnotation: map[string]v1.EnforcementAction{utils.CPURequestAnnotation: v1.EnforcementActionDeny},
+			},
+			warn: apis.ErrOutOfBoundsValue(0, 1, 1000, parallelismAnnotation),
+			err: &apis.FieldError{
+				Message: "Failed to parse quantity from rabbitmq.eventing.knative.dev/cpu-request",
+				Paths:   []string{"metadata", "annotations", "rabbitmq.eventing.knative.dev/cpu-request"},
+				Details: "quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+			},
+			objects: []runtime.Object{
+				validBroker("foo"),
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.WithValue(context.Background(), client.Key{}, fake.NewSimpleClientset(tc.objects...))
+
+			kc := kubefake.NewSimpleClientset()
+			kc.PrependReactor("create", "subjectaccessreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				sar := action.(kubetesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview).DeepCopy()
+				sar.Status.Allowed = tc.sarAllowed
+				return true, sar, nil
+			})
+			ctx = context.WithValue(ctx, kubeclient.Key{}, kc)
+			ctx = v1.WithEventPolicies(ctx, tc.policies)
+			ctx = v1.WithEnforcementConfig(ctx, tc.enforcement)
+
+			if !tc.noUserInfo {
+				ctx = apis.WithUserInfo(ctx, &authenticationv1.UserInfo{Username: "jane"})
+			}
+
 			if tc.original != nil {
 				t := eventingv1.Trigger{
 					TypeMeta:   tc.original.TypeMeta,
 					ObjectMeta: tc.original.ObjectMeta,
-					Spec:       tc.original.Spec,
+					Spec:       tc.original.Spec.TriggerSpec,
 					Status:     tc.original.Status,
 				}
 				ctx = apis.WithinUpdate(ctx, &t)
 			}
 
 			err := tc.trigger.Validate(ctx)
-			if diff := cmp.Diff(tc.err, err, cmpopts.IgnoreUnexported(apis.FieldError{})); diff != "" {
+			if diff := cmp.Diff(tc.err, err.Filter(apis.ErrorLevel), cmpopts.IgnoreUnexported(apis.FieldError{})); diff != "" {
 				t.Error("Trigger.Validate (-want, +got) =", diff)
 			}
+			if diff := cmp.Diff(tc.warn, err.Filter(apis.WarningLevel), cmpopts.IgnoreUnexported(apis.FieldError{})); diff != "" {
+				t.Error("Trigger.Validate warnings (-want, +got) =", diff)
+			}
 		})
 	}
 }
@@ -146,7 +377,7 @@ type triggerOpt func(*v1.RabbitTrigger)
 
 func trigger(opts ...triggerOpt) *v1.RabbitTrigger {
 	t := &v1.RabbitTrigger{
-		Spec: eventingv1.TriggerSpec{},
+		Spec: v1.RabbitTriggerSpec{},
 	}
 	for _, o := range opts {
 		o(t)
@@ -198,9 +429,46 @@ func withBroker(name string) triggerOpt {
 	}
 }
 
+func withBrokerRef(namespace, name string) triggerOpt {
+	return func(t *v1.RabbitTrigger) {
+		t.Spec.BrokerRef = &duckv1.KReference{
+			Namespace: namespace,
+			Name:      name,
+		}
+	}
+}
+
+func eventPolicy(name string, filters ...v1.SubscriptionsAPIFilter) *v1.EventPolicy {
+	return &v1.EventPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.EventPolicySpec{
+			From:    []v1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Kind: "Broker", Name: "foo"}}},
+			Filters: filters,
+		},
+	}
+}
+
+func withSubsFilters(filters ...v1.SubscriptionsAPIFilter) triggerOpt {
+	return func(t *v1.RabbitTrigger) {
+		t.Spec.Filters = append(t.Spec.Filters, filters...)
+	}
+}
+
+// cesqlParseErr computes the error a malformed cesql expression produces,
+// so tests don't have to hardcode a message owned by a third-party parser.
+func cesqlParseErr(expr string) error {
+	_, err := cesql.Parse(expr)
+	return err
+}
+
 func validBroker(name string) *eventingv1.Broker {
+	return validBrokerInNamespace("", name)
+}
+
+func validBrokerInNamespace(namespace, name string) *eventingv1.Broker {
 	return &eventingv1.Broker{
 		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
 			Name:        name,
 			Annotations: map[string]string{eventingv1.BrokerClassAnnotationKey: v1.BrokerClass},
 		},
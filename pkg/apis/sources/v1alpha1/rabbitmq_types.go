@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitmqSource is the Schema for a source that reads messages off of a
+// RabbitMQ queue and publishes them as CloudEvents to its Sink.
+type RabbitmqSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitmqSourceSpec   `json:"spec"`
+	Status RabbitmqSourceStatus `json:"status,omitempty"`
+}
+
+// RabbitmqSourceSpec defines the desired state of a RabbitmqSource.
+type RabbitmqSourceSpec struct {
+	// Brokers is the AMQP URI of the RabbitMQ cluster to consume from.
+	Brokers string `json:"brokers"`
+
+	// Topic is the exchange this source binds its queue to.
+	Topic string `json:"topic"`
+
+	ExchangeConfig RabbitmqSourceExchangeConfigSpec `json:"exchangeConfig,omitempty"`
+	QueueConfig    RabbitmqSourceQueueConfigSpec    `json:"queueConfig,omitempty"`
+	ChannelConfig  RabbitmqChannelConfigSpec        `json:"channelConfig,omitempty"`
+
+	// PayloadFormat tells the receive adapter which converters.Format to
+	// use when turning an AMQP message body into a CloudEvent. When empty,
+	// the format is resolved per delivery by converters.ResolveFormat from
+	// the message's header or content-type, and converters.FormatRaw is used
+	// when neither names one. Immutable after creation, since
+	// changing it would silently reinterpret events already in flight.
+	PayloadFormat string `json:"payloadFormat,omitempty"`
+
+	// Sink is the addressable that receives events read off the queue.
+	Sink *duckv1.Destination `json:"sink,omitempty"`
+
+	// ServiceAccountName is the Service Account used to run the receive
+	// adapter Deployment.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// RabbitmqSourceExchangeConfigSpec configures the exchange the source
+// consumes from.
+type RabbitmqSourceExchangeConfigSpec struct {
+	TypeOf      string `json:"name"`
+	Durable     bool   `json:"durable"`
+	AutoDeleted bool   `json:"autoDelete"`
+	Internal    bool   `json:"internal"`
+	NoWait      bool   `json:"noWait"`
+}
+
+// RabbitmqSourceQueueConfigSpec configures the queue the source binds to
+// the exchange.
+type RabbitmqSourceQueueConfigSpec struct {
+	Name             string `json:"name,omitempty"`
+	RoutingKey       string `json:"routingKey,omitempty"`
+	Durable          bool   `json:"durable,omitempty"`
+	DeleteWhenUnused bool   `json:"deleteWhenUnused,omitempty"`
+	Exclusive        bool   `json:"exclusive,omitempty"`
+	NoWait           bool   `json:"noWait,omitempty"`
+}
+
+// RabbitmqChannelConfigSpec tunes the AMQP channel the source reads from.
+type RabbitmqChannelConfigSpec struct {
+	// PrefetchCount bounds how many unacknowledged deliveries the channel
+	// will hold at once. Must be between 1 and 1000.
+	PrefetchCount *int `json:"prefetchCount,omitempty"`
+	GlobalQos     bool `json:"globalQos,omitempty"`
+}
+
+// RabbitmqSourceStatus defines the observed state of a RabbitmqSource.
+type RabbitmqSourceStatus struct {
+	duckv1.SourceStatus `json:",inline"`
+}
+
+// RabbitmqSourceList contains a list of RabbitmqSource.
+type RabbitmqSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitmqSource `json:"items"`
+}
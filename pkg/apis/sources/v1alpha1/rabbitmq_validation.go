@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	minChannelPrefetchCount = 1
+	maxChannelPrefetchCount = 1000
+)
+
+func (s *RabbitmqSource) Validate(ctx context.Context) *apis.FieldError {
+	errs := s.Spec.Validate(ctx).ViaField("spec")
+
+	if apis.IsInUpdate(ctx) {
+		if original, ok := apis.GetBaseline(ctx).(*RabbitmqSource); ok {
+			errs = errs.Also(s.CheckImmutableFields(ctx, &original.Spec))
+		}
+	}
+
+	return errs
+}
+
+// Validate checks the fields that don't depend on the previous version of
+// the spec, e.g. that ChannelConfig.PrefetchCount is in bounds.
+func (s *RabbitmqSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	if s.ChannelConfig.PrefetchCount == nil {
+		return nil
+	}
+
+	p := *s.ChannelConfig.PrefetchCount
+	if p < minChannelPrefetchCount || p > maxChannelPrefetchCount {
+		return apis.ErrOutOfBoundsValue(p, minChannelPrefetchCount, maxChannelPrefetchCount, "channelConfig.prefetchCount")
+	}
+
+	return nil
+}
+
+// CheckImmutableFields ensures that fields which cannot be changed after
+// creation haven't been. ChannelConfig.PrefetchCount is only immutable
+// while the source owns an exclusive queue -- once a queue is shared
+// (Exclusive: false), tuning prefetch on the fly is safe.
+func (s *RabbitmqSource) CheckImmutableFields(ctx context.Context, original *RabbitmqSourceSpec) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	if original.Topic != s.Spec.Topic {
+		return immutableFieldError("topic", original.Topic, s.Spec.Topic)
+	}
+
+	if original.Brokers != s.Spec.Brokers {
+		return immutableFieldError("brokers", original.Brokers, s.Spec.Brokers)
+	}
+
+	if original.ServiceAccountName != s.Spec.ServiceAccountName {
+		return immutableFieldError("serviceAccountName", original.ServiceAccountName, s.Spec.ServiceAccountName)
+	}
+
+	if original.PayloadFormat != s.Spec.PayloadFormat {
+		return immutableFieldError("payloadFormat", original.PayloadFormat, s.Spec.PayloadFormat)
+	}
+
+	if diff := sinkRefDiff(original.Sink, s.Spec.Sink); diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec", "sink", "ref"},
+			Details: diff,
+		}
+	}
+
+	if original.QueueConfig.Exclusive {
+		op, np := original.ChannelConfig.PrefetchCount, s.Spec.ChannelConfig.PrefetchCount
+		if (op == nil) != (np == nil) || (op != nil && np != nil && *op != *np) {
+			return &apis.FieldError{
+				Message: "Immutable fields changed (-old +new)",
+				Paths:   []string{"spec", "channelConfig", "prefetchCount"},
+				Details: "prefetchCount cannot change on a source backed by an exclusive queue",
+			}
+		}
+	}
+
+	return nil
+}
+
+func immutableFieldError(field, old, new string) *apis.FieldError {
+	return &apis.FieldError{
+		Message: "Immutable fields changed (-old +new)",
+		Paths:   []string{"spec", field},
+		Details: fmt.Sprintf("-: %q\n+: %q\n", old, new),
+	}
+}
+
+func sinkRefDiff(original, current *duckv1.Destination) string {
+	if original == nil || original.Ref == nil {
+		if current == nil || current.Ref == nil {
+			return ""
+		}
+		return fmt.Sprintf("-: %q\n+: %+v\n", "", current.Ref)
+	}
+	if current == nil || current.Ref == nil {
+		return fmt.Sprintf("-: %+v\n+: %q\n", original.Ref, "")
+	}
+
+	o, n := original.Ref, current.Ref
+	if o.APIVersion != n.APIVersion || o.Kind != n.Kind || o.Namespace != n.Namespace || o.Name != n.Name {
+		return fmt.Sprintf("-: %+v\n+: %+v\n", o, n)
+	}
+	return ""
+}
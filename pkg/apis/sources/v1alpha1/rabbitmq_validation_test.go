@@ -152,6 +152,17 @@ func TestRabbitmqSourceCheckImmutableFields(t *testing.T) {
 			},
 			allowed: false,
 		},
+		"PayloadFormat changed": {
+			orig: &fullSpec,
+			updated: RabbitmqSourceSpec{
+				Topic:              fullSpec.Topic,
+				Brokers:            fullSpec.Brokers,
+				Sink:               fullSpec.Sink,
+				ServiceAccountName: fullSpec.ServiceAccountName,
+				PayloadFormat:      "binary-json",
+			},
+			allowed: false,
+		},
 		"ServiceAccountName changed": {
 			orig: &fullSpec,
 			updated: RabbitmqSourceSpec{
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventpolicy enforces EventPolicy producer-identity and filter
+// gating at delivery time. The webhook (see
+// pkg/apis/eventing/v1alpha1/trigger_event_policy.go) can only catch
+// admission-time contradictions between a Trigger's own filter and an
+// EventPolicy's; it has no delivery to check an EventPolicy's From against.
+// A RabbitTrigger's dispatcher calls Authorized for every delivery instead,
+// before acking it.
+package eventpolicy
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/dispatcher/filter"
+)
+
+// Producer identifies whoever is attempting to deliver event, either by the
+// object it was sent on behalf of or by the OIDC identity presented with
+// the delivery request. Exactly one is expected to be set, mirroring
+// EventPolicyFromSpec.
+type Producer struct {
+	// Ref identifies the producer by source object, e.g. the Broker or
+	// Source the dispatcher received event from.
+	Ref *duckv1.KReference
+	// OIDC identifies the producer by the service account whose token
+	// authenticated the delivery request.
+	OIDC *v1alpha1.EventPolicyFromOIDC
+}
+
+// Authorized reports whether producer is allowed to deliver event to a
+// RabbitTrigger in triggerNamespace backed by broker, given policies -- the
+// EventPolicies visible to the dispatcher. If none of policies applies (per
+// EventPolicy.AppliesTo), delivery is unrestricted and Authorized returns
+// true: an EventPolicy narrows an otherwise-open Trigger, it doesn't open a
+// closed one. Once at least one policy applies, producer must match one
+// applicable policy's From and event must satisfy that policy's Filters.
+func Authorized(policies []*v1alpha1.EventPolicy, triggerNamespace, triggerName string, broker *eventingv1.Broker, producer Producer, event cloudevents.Event) bool {
+	applicable := false
+	for _, p := range policies {
+		if !p.AppliesTo(triggerNamespace, triggerName, broker) {
+			continue
+		}
+		applicable = true
+
+		if matchesProducer(p, producer) && filter.Match(p.Spec.Filters, event) {
+			return true
+		}
+	}
+	return !applicable
+}
+
+func matchesProducer(p *v1alpha1.EventPolicy, producer Producer) bool {
+	for _, from := range p.Spec.From {
+		if matchesFrom(from, p.Namespace, producer) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesFrom(from v1alpha1.EventPolicyFromSpec, policyNamespace string, producer Producer) bool {
+	switch {
+	case from.Ref != nil:
+		return producer.Ref != nil && refMatches(*from.Ref, policyNamespace, *producer.Ref)
+	case from.OIDC != nil:
+		return producer.OIDC != nil && oidcMatches(*from.OIDC, policyNamespace, *producer.OIDC)
+	default:
+		return false
+	}
+}
+
+// refMatches reports whether got identifies the same object as want,
+// defaulting want's Namespace to defaultNamespace when it's unset, the same
+// way EventPolicyFromOIDC.Namespace defaults.
+func refMatches(want duckv1.KReference, defaultNamespace string, got duckv1.KReference) bool {
+	wantNamespace := want.Namespace
+	if wantNamespace == "" {
+		wantNamespace = defaultNamespace
+	}
+	if wantNamespace != got.Namespace || want.Name != got.Name {
+		return false
+	}
+	if want.Kind != "" && want.Kind != got.Kind {
+		return false
+	}
+	if want.APIVersion != "" && want.APIVersion != got.APIVersion {
+		return false
+	}
+	return true
+}
+
+// oidcMatches reports whether got identifies the same service account as
+// want, defaulting either side's Namespace to defaultNamespace when unset.
+func oidcMatches(want v1alpha1.EventPolicyFromOIDC, defaultNamespace string, got v1alpha1.EventPolicyFromOIDC) bool {
+	wantNamespace := want.Namespace
+	if wantNamespace == "" {
+		wantNamespace = defaultNamespace
+	}
+	gotNamespace := got.Namespace
+	if gotNamespace == "" {
+		gotNamespace = defaultNamespace
+	}
+	return wantNamespace == gotNamespace && want.ServiceAccount == got.ServiceAccount
+}
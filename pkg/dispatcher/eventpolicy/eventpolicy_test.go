@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventpolicy
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+)
+
+func rabbitBroker(namespace, name string) *eventingv1.Broker {
+	return &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: map[string]string{eventingv1.BrokerClassAnnotationKey: v1alpha1.BrokerClass},
+		},
+	}
+}
+
+func fooEvent() cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetType("dev.knative.foo")
+	event.SetSource("unittest")
+	return event
+}
+
+func TestAuthorized(t *testing.T) {
+	broker := rabbitBroker("ns", "broker")
+
+	nonRabbitBroker := &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "broker",
+			Annotations: map[string]string{eventingv1.BrokerClassAnnotationKey: "some-other-broker"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		broker   *eventingv1.Broker
+		policies []*v1alpha1.EventPolicy
+		producer Producer
+		want     bool
+	}{
+		{
+			name: "no applicable policies leaves delivery unrestricted",
+			want: true,
+		},
+		{
+			name: "producer matches by ref and satisfies filters",
+			policies: []*v1alpha1.EventPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+					Spec: v1alpha1.EventPolicySpec{
+						From: []v1alpha1.EventPolicyFromSpec{{
+							Ref: &duckv1.KReference{Kind: "Broker", Namespace: "producer-ns", Name: "other-broker"},
+						}},
+					},
+				},
+			},
+			producer: Producer{Ref: &duckv1.KReference{Kind: "Broker", Namespace: "producer-ns", Name: "other-broker"}},
+			want:     true,
+		},
+		{
+			name: "producer ref doesn't match any From",
+			policies: []*v1alpha1.EventPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+					Spec: v1alpha1.EventPolicySpec{
+						From: []v1alpha1.EventPolicyFromSpec{{
+							Ref: &duckv1.KReference{Kind: "Broker", Namespace: "producer-ns", Name: "other-broker"},
+						}},
+					},
+				},
+			},
+			producer: Producer{Ref: &duckv1.KReference{Kind: "Broker", Namespace: "producer-ns", Name: "someone-else"}},
+			want:     false,
+		},
+		{
+			name: "producer matches by OIDC identity, defaulting namespace to the policy's own",
+			policies: []*v1alpha1.EventPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+					Spec: v1alpha1.EventPolicySpec{
+						From: []v1alpha1.EventPolicyFromSpec{{
+							OIDC: &v1alpha1.EventPolicyFromOIDC{ServiceAccount: "producer-sa"},
+						}},
+					},
+				},
+			},
+			producer: Producer{OIDC: &v1alpha1.EventPolicyFromOIDC{Namespace: "ns", ServiceAccount: "producer-sa"}},
+			want:     true,
+		},
+		{
+			name: "producer matches From but event fails the policy's filters",
+			policies: []*v1alpha1.EventPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+					Spec: v1alpha1.EventPolicySpec{
+						From:    []v1alpha1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Name: "other-broker"}}},
+						Filters: []v1alpha1.SubscriptionsAPIFilter{{Exact: map[string]string{"type": "dev.knative.bar"}}},
+					},
+				},
+			},
+			producer: Producer{Ref: &duckv1.KReference{Name: "other-broker", Namespace: "ns"}},
+			want:     false,
+		},
+		{
+			name: "policy for a different Trigger doesn't restrict this one",
+			policies: []*v1alpha1.EventPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+					Spec: v1alpha1.EventPolicySpec{
+						To:   &duckv1.KReference{Name: "other-trigger"},
+						From: []v1alpha1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Name: "other-broker"}}},
+					},
+				},
+			},
+			producer: Producer{Ref: &duckv1.KReference{Name: "someone-unlisted"}},
+			want:     true,
+		},
+		{
+			name:   "policy scoped to a different broker class doesn't restrict this one",
+			broker: nonRabbitBroker,
+			policies: []*v1alpha1.EventPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+					Spec: v1alpha1.EventPolicySpec{
+						From: []v1alpha1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Name: "other-broker"}}},
+					},
+				},
+			},
+			producer: Producer{Ref: &duckv1.KReference{Name: "someone-unlisted"}},
+			want:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := tc.broker
+			if b == nil {
+				b = broker
+			}
+			if got := Authorized(tc.policies, "ns", "trigger", b, tc.producer, fooEvent()); got != tc.want {
+				t.Errorf("Authorized() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
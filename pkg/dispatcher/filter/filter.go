@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filter evaluates the CE subscriptions-API filter dialects
+// (exact, prefix, suffix, all, any, not, cesql) against a delivered
+// CloudEvent. It lets the RabbitTrigger dispatcher express predicates that
+// a RabbitMQ header binding can't, since the dialects are evaluated
+// in-process before the delivery is ack'd rather than by the broker.
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	cesql "github.com/cloudevents/sdk-go/sql/v2"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	eventingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+)
+
+// Match reports whether event satisfies every filter in filters. An empty
+// filters list always matches, mirroring the legacy behavior of a Trigger
+// with no attribute filter.
+func Match(filters []eventingv1alpha1.SubscriptionsAPIFilter, event cloudevents.Event) bool {
+	for _, f := range filters {
+		if !match(f, event) {
+			return false
+		}
+	}
+	return true
+}
+
+func match(f eventingv1alpha1.SubscriptionsAPIFilter, event cloudevents.Event) bool {
+	switch {
+	case f.Exact != nil:
+		return matchAttributes(f.Exact, event, func(value, want string) bool {
+			return value == want
+		})
+	case f.Prefix != nil:
+		return matchAttributes(f.Prefix, event, strings.HasPrefix)
+	case f.Suffix != nil:
+		return matchAttributes(f.Suffix, event, strings.HasSuffix)
+	case f.All != nil:
+		return Match(f.All, event)
+	case f.Any != nil:
+		for _, nested := range f.Any {
+			if match(nested, event) {
+				return true
+			}
+		}
+		return false
+	case f.Not != nil:
+		return !match(*f.Not, event)
+	case f.CESQL != "":
+		expr, err := compiledCESQL(f.CESQL)
+		if err != nil {
+			// The webhook rejects expressions that don't compile, so this
+			// should be unreachable in practice.
+			return false
+		}
+		result, err := expr.Evaluate(cesql.EventExpressionEnvironment(event))
+		return err == nil && result == true
+	default:
+		return false
+	}
+}
+
+// cesqlCache memoizes cesql.Parse by expression string, so a CESQL filter
+// -- already compiled once at admission time by filter_validation.go -- is
+// compiled at most once per dispatcher process rather than on every single
+// delivery.
+var (
+	cesqlCacheMu sync.Mutex
+	cesqlCache   = map[string]cesql.Expression{}
+)
+
+func compiledCESQL(expr string) (cesql.Expression, error) {
+	cesqlCacheMu.Lock()
+	defer cesqlCacheMu.Unlock()
+
+	if compiled, ok := cesqlCache[expr]; ok {
+		return compiled, nil
+	}
+
+	compiled, err := cesql.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	cesqlCache[expr] = compiled
+	return compiled, nil
+}
+
+func matchAttributes(want map[string]string, event cloudevents.Event, cmp func(value, want string) bool) bool {
+	for attr, wantValue := range want {
+		value, ok := attributeValue(event, attr)
+		if !ok || !cmp(value, wantValue) {
+			return false
+		}
+	}
+	return true
+}
+
+func attributeValue(event cloudevents.Event, attr string) (string, bool) {
+	if attr == "type" {
+		return event.Type(), true
+	}
+	if ext, ok := event.Extensions()[attr]; ok {
+		return fmt.Sprintf("%v", ext), true
+	}
+	ctx := event.Context
+	switch attr {
+	case "source":
+		return ctx.GetSource(), true
+	case "subject":
+		return ctx.GetSubject(), true
+	case "id":
+		return ctx.GetID(), true
+	case "datacontenttype":
+		return ctx.GetDataContentType(), true
+	}
+	return "", false
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	eventingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+)
+
+func TestMatch(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetType("dev.knative.foo.bar")
+	event.SetSource("unittest")
+	event.SetExtension("myextension", "myvalue")
+
+	tests := []struct {
+		name    string
+		filters []eventingv1alpha1.SubscriptionsAPIFilter
+		want    bool
+	}{
+		{
+			name: "no filters matches everything",
+			want: true,
+		},
+		{
+			name: "exact match",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{Exact: map[string]string{"type": "dev.knative.foo.bar"}},
+			},
+			want: true,
+		},
+		{
+			name: "exact mismatch",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{Exact: map[string]string{"type": "dev.knative.other"}},
+			},
+			want: false,
+		},
+		{
+			name: "prefix match",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{Prefix: map[string]string{"type": "dev.knative"}},
+			},
+			want: true,
+		},
+		{
+			name: "suffix mismatch",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{Suffix: map[string]string{"type": ".baz"}},
+			},
+			want: false,
+		},
+		{
+			name: "any matches if one nested filter matches",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{Any: []eventingv1alpha1.SubscriptionsAPIFilter{
+					{Exact: map[string]string{"type": "dev.knative.other"}},
+					{Exact: map[string]string{"source": "unittest"}},
+				}},
+			},
+			want: true,
+		},
+		{
+			name: "not inverts the nested result",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{Not: &eventingv1alpha1.SubscriptionsAPIFilter{
+					Exact: map[string]string{"type": "dev.knative.other"},
+				}},
+			},
+			want: true,
+		},
+		{
+			name: "extension attribute match",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{Exact: map[string]string{"myextension": "myvalue"}},
+			},
+			want: true,
+		},
+		{
+			name: "cesql match",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{CESQL: "type = 'dev.knative.foo.bar'"},
+			},
+			want: true,
+		},
+		{
+			name: "cesql mismatch",
+			filters: []eventingv1alpha1.SubscriptionsAPIFilter{
+				{CESQL: "type = 'dev.knative.other'"},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Match(tc.filters, event); got != tc.want {
+				t.Errorf("Match() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestMatchCESQLCachesCompiledExpression exercises the same CESQL
+// expression across repeated Match calls (mirroring a dispatcher
+// evaluating one filter against many deliveries), confirming the cached
+// compiled expression still matches correctly on every call rather than
+// just the first.
+func TestMatchCESQLCachesCompiledExpression(t *testing.T) {
+	filters := []eventingv1alpha1.SubscriptionsAPIFilter{
+		{CESQL: "type = 'dev.knative.repeat'"},
+	}
+
+	matching := cloudevents.NewEvent()
+	matching.SetType("dev.knative.repeat")
+	matching.SetSource("unittest")
+
+	other := cloudevents.NewEvent()
+	other.SetType("dev.knative.other")
+	other.SetSource("unittest")
+
+	for i := 0; i < 3; i++ {
+		if !Match(filters, matching) {
+			t.Errorf("iteration %d: Match(matching) = false, want true", i)
+		}
+		if Match(filters, other) {
+			t.Errorf("iteration %d: Match(other) = true, want false", i)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package handler is the RabbitTrigger dispatcher's per-delivery entry
+// point. It takes the CloudEvent decoded from a RabbitMQ delivery, reports
+// probe events instead of forwarding them, drops it in-process (without
+// forwarding it) if it doesn't satisfy the Trigger's filters or isn't
+// authorized by an applicable EventPolicy, and otherwise forwards it to the
+// Trigger's Subscriber -- all before the dispatcher acks the underlying
+// RabbitMQ delivery.
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/dispatcher/eventpolicy"
+	"knative.dev/eventing-rabbitmq/pkg/dispatcher/filter"
+	"knative.dev/eventing-rabbitmq/pkg/dispatcher/probe"
+)
+
+// Handler forwards CloudEvents that satisfy both Filters and whatever
+// EventPolicies apply to Send, and reports probe events back to OnProbe
+// instead. It's the dispatcher's call site for filter.Match (a RabbitMQ
+// header binding can't express the subscriptions-API filter dialects, so
+// the dispatcher evaluates them itself before deciding whether to ack a
+// delivery), eventpolicy.Authorized (likewise the only place an
+// EventPolicy's From and Filters are checked against an actual delivery),
+// and probe.IsProbe (a probe must round-trip through the same path a real
+// event would, without being subject to either check).
+type Handler struct {
+	// Filters are evaluated in-process against every delivered event,
+	// using the same dialects as RabbitTriggerSpec.Filters. An empty list
+	// always matches.
+	Filters []v1alpha1.SubscriptionsAPIFilter
+	// Namespace and TriggerName identify the RabbitTrigger this Handler
+	// serves, for evaluating which Policies apply to it.
+	Namespace, TriggerName string
+	// Broker is the RabbitMQ-backed Broker backing the Trigger, also
+	// needed to evaluate which Policies apply to it.
+	Broker *eventingv1.Broker
+	// Policies are the EventPolicies visible to the dispatcher.
+	Policies []*v1alpha1.EventPolicy
+	// Producer identifies this dispatcher's own deliveries, checked
+	// against an applicable EventPolicy's From.
+	Producer eventpolicy.Producer
+	// Send delivers event to the Trigger's Subscriber.
+	Send func(ctx context.Context, event cloudevents.Event) error
+	// OnProbe, if set, is called with a probe event's id in place of Send
+	// -- typically feeding the send side of the channel underlying a
+	// probe.Prober's Acked, so the controller's Probe call unblocks.
+	OnProbe func(id string)
+}
+
+// Handle reports whether the RabbitMQ delivery event was decoded from
+// should be acked. A probe event is reported to OnProbe and acked without
+// being sent, filtered, or checked against Policies. An event that doesn't
+// satisfy Filters, or isn't authorized by an applicable EventPolicy, is
+// dropped and acked the same way, the same as one a RabbitMQ header
+// binding never routed to this queue in the first place. Only a genuine
+// Send failure leaves ack false, so the dispatcher redelivers it.
+func (h *Handler) Handle(ctx context.Context, event cloudevents.Event) (ack bool, err error) {
+	if probe.IsProbe(event) {
+		if h.OnProbe != nil {
+			h.OnProbe(event.ID())
+		}
+		return true, nil
+	}
+
+	if !filter.Match(h.Filters, event) {
+		return true, nil
+	}
+
+	if !eventpolicy.Authorized(h.Policies, h.Namespace, h.TriggerName, h.Broker, h.Producer, event) {
+		return true, nil
+	}
+
+	if err := h.Send(ctx, event); err != nil {
+		return false, fmt.Errorf("forwarding event %s to subscriber: %w", event.ID(), err)
+	}
+
+	return true, nil
+}
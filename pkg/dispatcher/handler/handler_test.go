@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/dispatcher/eventpolicy"
+	"knative.dev/eventing-rabbitmq/pkg/dispatcher/probe"
+)
+
+func rabbitBroker() *eventingv1.Broker {
+	return &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "broker",
+			Annotations: map[string]string{eventingv1.BrokerClassAnnotationKey: v1alpha1.BrokerClass},
+		},
+	}
+}
+
+func fooEvent() cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetType("dev.knative.foo")
+	event.SetSource("unittest")
+	return event
+}
+
+func TestHandleMatchingEventIsSentAndAcked(t *testing.T) {
+	var sent cloudevents.Event
+	h := &Handler{
+		Filters: []v1alpha1.SubscriptionsAPIFilter{{Exact: map[string]string{"type": "dev.knative.foo"}}},
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			sent = event
+			return nil
+		},
+	}
+
+	ack, err := h.Handle(context.Background(), fooEvent())
+	if err != nil {
+		t.Fatalf("Handle() returned error: %v", err)
+	}
+	if !ack {
+		t.Error("ack = false, want true")
+	}
+	if sent.Type() != "dev.knative.foo" {
+		t.Errorf("Send wasn't called with the matching event, got %+v", sent)
+	}
+}
+
+func TestHandleNonMatchingEventIsDroppedButAcked(t *testing.T) {
+	sendCalled := false
+	h := &Handler{
+		Filters: []v1alpha1.SubscriptionsAPIFilter{{Exact: map[string]string{"type": "dev.knative.bar"}}},
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			sendCalled = true
+			return nil
+		},
+	}
+
+	ack, err := h.Handle(context.Background(), fooEvent())
+	if err != nil {
+		t.Fatalf("Handle() returned error: %v", err)
+	}
+	if !ack {
+		t.Error("ack = false, want true for a filtered-out event")
+	}
+	if sendCalled {
+		t.Error("Send was called for an event that didn't satisfy Filters")
+	}
+}
+
+func TestHandleUnauthorizedEventIsDroppedButAcked(t *testing.T) {
+	sendCalled := false
+	h := &Handler{
+		Namespace:   "ns",
+		TriggerName: "trigger",
+		Broker:      rabbitBroker(),
+		Policies: []*v1alpha1.EventPolicy{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+				Spec: v1alpha1.EventPolicySpec{
+					From: []v1alpha1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Name: "allowed-broker"}}},
+				},
+			},
+		},
+		Producer: eventpolicy.Producer{Ref: &duckv1.KReference{Name: "someone-unlisted"}},
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			sendCalled = true
+			return nil
+		},
+	}
+
+	ack, err := h.Handle(context.Background(), fooEvent())
+	if err != nil {
+		t.Fatalf("Handle() returned error: %v", err)
+	}
+	if !ack {
+		t.Error("ack = false, want true for an unauthorized event")
+	}
+	if sendCalled {
+		t.Error("Send was called for an event no applicable EventPolicy authorizes")
+	}
+}
+
+func TestHandleAuthorizedEventIsSentAndAcked(t *testing.T) {
+	var sent cloudevents.Event
+	h := &Handler{
+		Namespace:   "ns",
+		TriggerName: "trigger",
+		Broker:      rabbitBroker(),
+		Policies: []*v1alpha1.EventPolicy{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"},
+				Spec: v1alpha1.EventPolicySpec{
+					From: []v1alpha1.EventPolicyFromSpec{{Ref: &duckv1.KReference{Name: "allowed-broker"}}},
+				},
+			},
+		},
+		Producer: eventpolicy.Producer{Ref: &duckv1.KReference{Name: "allowed-broker", Namespace: "ns"}},
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			sent = event
+			return nil
+		},
+	}
+
+	ack, err := h.Handle(context.Background(), fooEvent())
+	if err != nil {
+		t.Fatalf("Handle() returned error: %v", err)
+	}
+	if !ack {
+		t.Error("ack = false, want true")
+	}
+	if sent.Type() != "dev.knative.foo" {
+		t.Errorf("Send wasn't called with the authorized event, got %+v", sent)
+	}
+}
+
+func TestHandleProbeEventIsReportedAndAckedWithoutSending(t *testing.T) {
+	var gotID string
+	sendCalled := false
+	h := &Handler{
+		Filters: []v1alpha1.SubscriptionsAPIFilter{{Exact: map[string]string{"type": "dev.knative.bar"}}},
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			sendCalled = true
+			return nil
+		},
+		OnProbe: func(id string) {
+			gotID = id
+		},
+	}
+
+	probeEvent := probe.New("unittest", "probe-1")
+	ack, err := h.Handle(context.Background(), probeEvent)
+	if err != nil {
+		t.Fatalf("Handle() returned error: %v", err)
+	}
+	if !ack {
+		t.Error("ack = false, want true for a probe event")
+	}
+	if sendCalled {
+		t.Error("Send was called for a probe event")
+	}
+	if gotID != "probe-1" {
+		t.Errorf("OnProbe id = %q, want %q", gotID, "probe-1")
+	}
+}
+
+func TestHandleSendFailureIsNotAcked(t *testing.T) {
+	h := &Handler{
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			return errors.New("subscriber unreachable")
+		},
+	}
+
+	ack, err := h.Handle(context.Background(), fooEvent())
+	if err == nil {
+		t.Fatal("Handle() returned nil error, want one")
+	}
+	if ack {
+		t.Error("ack = true, want false when Send fails")
+	}
+}
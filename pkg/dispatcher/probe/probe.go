@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe drives the controller's liveness/readiness check for a
+// RabbitTrigger's dispatcher. It publishes a synthetic CloudEvent carrying
+// a distinguishing extension attribute through the same queue a real event
+// takes, and waits for the dispatcher to report its id back once it has
+// received it -- catching dispatchers that are up but have stopped draining
+// the queue. The dispatcher does not forward a probe to the Subscriber.
+package probe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+const (
+	// ExtensionName is the CloudEvents extension attribute set on every
+	// probe event, so a dispatcher (or anything else watching the
+	// topology) can tell a probe apart from a real delivery.
+	ExtensionName = "knprobe"
+	// EventType is the CloudEvents type of a probe event.
+	EventType = "dev.knative.rabbitmq.trigger.probe"
+)
+
+// New returns a probe CloudEvent carrying id, which the caller later
+// matches against the delivery it (hopefully) gets back.
+func New(source, id string) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetSource(source)
+	event.SetType(EventType)
+	event.SetExtension(ExtensionName, true)
+	return event
+}
+
+// IsProbe reports whether event was produced by New -- i.e. whether the
+// dispatcher should treat it as a liveness check rather than a real
+// delivery needing ordinary filtering and delivery semantics.
+func IsProbe(event cloudevents.Event) bool {
+	v, ok := event.Extensions()[ExtensionName]
+	return ok && fmt.Sprintf("%v", v) == "true"
+}
+
+// Prober publishes probe events through Send and waits for the dispatcher
+// to echo their id back on Acked.
+type Prober struct {
+	// Send publishes event through the Trigger's dispatcher.
+	Send func(ctx context.Context, event cloudevents.Event) error
+	// Acked receives a probe's id once the dispatcher has received and
+	// acked it.
+	Acked <-chan string
+}
+
+// Probe publishes a fresh probe event through p.Send, then waits up to
+// timeout for its id to arrive on p.Acked. The returned error, if any, is
+// suitable for RabbitTrigger.MarkProbeFailed.
+func (p *Prober) Probe(ctx context.Context, source string, timeout time.Duration) error {
+	id := fmt.Sprintf("probe-%d", time.Now().UnixNano())
+
+	if err := p.Send(ctx, New(source, id)); err != nil {
+		return fmt.Errorf("publishing probe event: %w", err)
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case got, ok := <-p.Acked:
+			if !ok {
+				return fmt.Errorf("probe %s: ack channel closed before a reply arrived", id)
+			}
+			if got == id {
+				return nil
+			}
+		case <-deadline.C:
+			return fmt.Errorf("probe %s: no ack received within %s", id, timeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestIsProbe(t *testing.T) {
+	probeEvent := New("unittest", "probe-1")
+	if !IsProbe(probeEvent) {
+		t.Error("IsProbe(probe event) = false, want true")
+	}
+
+	ordinary := cloudevents.NewEvent()
+	ordinary.SetType("dev.knative.foo.bar")
+	if IsProbe(ordinary) {
+		t.Error("IsProbe(ordinary event) = true, want false")
+	}
+}
+
+func TestProbeSucceeds(t *testing.T) {
+	acked := make(chan string, 1)
+	p := &Prober{
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			acked <- event.ID()
+			return nil
+		},
+		Acked: acked,
+	}
+
+	if err := p.Probe(context.Background(), "unittest", time.Second); err != nil {
+		t.Fatalf("Probe() returned error: %v", err)
+	}
+}
+
+func TestProbeFailsWhenSendFails(t *testing.T) {
+	p := &Prober{
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			return errors.New("dispatcher unreachable")
+		},
+		Acked: make(chan string),
+	}
+
+	if err := p.Probe(context.Background(), "unittest", time.Second); err == nil {
+		t.Fatal("Probe() returned nil error, want one")
+	}
+}
+
+func TestProbeFailsOnTimeout(t *testing.T) {
+	p := &Prober{
+		Send: func(ctx context.Context, event cloudevents.Event) error {
+			return nil
+		},
+		Acked: make(chan string),
+	}
+
+	if err := p.Probe(context.Background(), "unittest", 10*time.Millisecond); err == nil {
+		t.Fatal("Probe() returned nil error, want a timeout error")
+	}
+}